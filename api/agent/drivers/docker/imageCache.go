@@ -1,8 +1,9 @@
 package docker
 
 import (
+	"container/heap"
+	"context"
 	"errors"
-	"sort"
 	"sync"
 	"time"
 
@@ -10,160 +11,314 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// Cache is an LRU cache, safe for concurrent access.
-type Cache struct {
-	totalSize int64
-	mu        sync.Mutex
-	cache     EntryByAge
-	maxSize   int64
+// ErrImageNotFound is returned by Cache methods when the requested image
+// ID is not present in the cache.
+var ErrImageNotFound = errors.New("image not found in cache")
+
+// Backend removes an image from the underlying image store. It exists so
+// Cache does not have to couple itself to any particular Docker client.
+type Backend interface {
+	RemoveImage(ctx context.Context, id string) error
+}
+
+// entry is a single cached image, along with its LRU bookkeeping. heapIndex
+// is maintained by container/heap and is -1 whenever the entry is not
+// currently a member of the eviction heap (e.g. while locked).
+type entry struct {
+	lastUsed  time.Time
+	uses      int64
+	locked    bool
+	heapIndex int
+	image     d.APIImages
 }
 
-type Entry struct {
-	lastUsed time.Time
-	locked   bool
-	uses     int64
-	image    d.APIImages
+// Score ranks entries for eviction: the higher the score, the more
+// eligible the entry is to be evicted (old and rarely used first).
+func (e *entry) Score() int64 {
+	return time.Since(e.lastUsed).Nanoseconds() / e.uses
 }
 
-func (e Entry) Score() int64 {
-	age := time.Now().Sub(e.lastUsed)
-	return age.Nanoseconds() / e.uses
+func newEntry(value d.APIImages) *entry {
+	return &entry{
+		lastUsed:  time.Now(),
+		uses:      1,
+		image:     value,
+		heapIndex: -1,
+	}
 }
 
-type EntryByAge []Entry
+// entryHeap is a max-heap ordered by Score, so the root is always the
+// best eviction candidate. Only unlocked entries live in the heap.
+type entryHeap []*entry
 
-func (a EntryByAge) Len() int           { return len(a) }
-func (a EntryByAge) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a EntryByAge) Less(i, j int) bool { return a[i].Score() < a[j].Score() }
+func (h entryHeap) Len() int           { return len(h) }
+func (h entryHeap) Less(i, j int) bool { return h[i].Score() > h[j].Score() }
+func (h entryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIndex = i
+	h[j].heapIndex = j
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.heapIndex = len(*h)
+	*h = append(*h, e)
+}
 
-func NewEntry(value d.APIImages) Entry {
-	return Entry{
-		lastUsed: time.Now(),
-		locked:   false,
-		uses:     0,
-		image:    value}
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.heapIndex = -1
+	*h = old[:n-1]
+	return e
 }
 
-// New returns a new cache with the provided maximum items.
-func NewCache(maxSize int64) *Cache {
+// Cache is a size-bounded, LRU-ish cache of Docker images, safe for
+// concurrent access. Entries are indexed by ID for O(1) lookups and kept
+// in a heap ordered by Score() so eviction picks the best candidate in
+// O(log n) instead of scanning the whole cache.
+type Cache struct {
+	mu        sync.Mutex
+	items     map[string]*entry
+	evictable entryHeap
+	totalSize int64
+	maxSize   int64
+
+	backend Backend
+
+	// highWatermark triggers background eviction once totalSize exceeds
+	// it; lowWatermark is the target totalSize to evict down to.
+	highWatermark int64
+	lowWatermark  int64
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewCache returns a new Cache that evicts images once it holds more than
+// maxSize bytes, using backend to actually remove evicted images.
+func NewCache(maxSize int64, backend Backend) *Cache {
 	return &Cache{
-		cache: make(EntryByAge, 0),
+		items:         make(map[string]*entry),
+		maxSize:       maxSize,
+		backend:       backend,
+		highWatermark: maxSize,
+		lowWatermark:  maxSize - maxSize/10, // default to a 10% buffer
+		stop:          make(chan struct{}),
 	}
 }
 
+// WithWatermarks overrides the default high/low watermarks used by Run to
+// decide when to start, and how far to drive, background eviction.
+func (c *Cache) WithWatermarks(high, low int64) *Cache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.highWatermark = high
+	c.lowWatermark = low
+	return c
+}
+
+// Contains returns whether value is present in the cache.
 func (c *Cache) Contains(value d.APIImages) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	for _, i := range c.cache {
-		if i.image.ID == value.ID {
-			return true
-		}
-	}
-	return false
+	_, ok := c.items[value.ID]
+	return ok
 }
 
+// Mark records a use of the image ID, refreshing its LRU position.
 func (c *Cache) Mark(ID string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	for idx, i := range c.cache {
-		if i.image.ID == ID {
-			c.cache[idx].lastUsed = time.Now()
-			c.cache[idx].uses = c.cache[idx].uses + 1
-			return nil
-		}
+	e, ok := c.items[ID]
+	if !ok {
+		return ErrImageNotFound
 	}
-
-	return errors.New("Image not found in cache")
+	e.lastUsed = time.Now()
+	e.uses++
+	if e.heapIndex >= 0 {
+		heap.Fix(&c.evictable, e.heapIndex)
+	}
+	return nil
 }
 
+// Remove removes value from the cache, regardless of its locked state.
 func (c *Cache) Remove(value d.APIImages) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	for idx, i := range c.cache {
-		if i.image.ID == value.ID {
-			// Move the last item into the location of the item to be removed
-			c.cache[idx] = c.cache[len(c.cache)-1]
-			// shorten the list
-			c.cache = c.cache[:len(c.cache)-1]
-			return nil
-		}
+	e, ok := c.items[value.ID]
+	if !ok {
+		return ErrImageNotFound
 	}
+	c.removeEntry(e)
+	return nil
+}
 
-	return errors.New("Image not found in cache")
+// removeEntry removes e from the index, the heap (if present) and
+// totalSize. Callers must hold c.mu.
+func (c *Cache) removeEntry(e *entry) {
+	delete(c.items, e.image.ID)
+	if e.heapIndex >= 0 {
+		heap.Remove(&c.evictable, e.heapIndex)
+	}
+	c.totalSize -= e.image.Size
 }
 
+// Lock marks ID as in-use, excluding it from eviction until Unlock.
 func (c *Cache) Lock(ID string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	for _, i := range c.cache {
-		if i.image.ID == ID {
-			i.locked = true
-			return nil
-		}
+	e, ok := c.items[ID]
+	if !ok {
+		return ErrImageNotFound
 	}
-	return errors.New("Image not found in cache")
+	e.locked = true
+	if e.heapIndex >= 0 {
+		heap.Remove(&c.evictable, e.heapIndex)
+	}
+	return nil
 }
 
+// Locked returns whether value is currently locked against eviction.
 func (c *Cache) Locked(value d.APIImages) (bool, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	for _, i := range c.cache {
-		if i.image.ID == value.ID {
-			return i.locked, nil
-		}
+	e, ok := c.items[value.ID]
+	if !ok {
+		return false, ErrImageNotFound
 	}
-	return false, errors.New("Image not found in cache")
+	return e.locked, nil
 }
 
+// Unlock clears value's locked state, making it eligible for eviction
+// again.
 func (c *Cache) Unlock(value d.APIImages) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	for _, i := range c.cache {
-		if i.image.ID == value.ID {
-			i.locked = false
-		}
+	e, ok := c.items[value.ID]
+	if !ok || !e.locked {
+		return
 	}
+	e.locked = false
+	heap.Push(&c.evictable, e)
 }
 
-// Add adds the provided key and value to the cache, evicting
-// an old item if necessary.
+// Add adds value to the cache, or marks it as used if it's already
+// present.
 func (c *Cache) Add(value d.APIImages) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	logrus.Debugf("value: %v", value)
-	if c.Contains(value) {
-		c.Mark(value.ID)
+
+	if e, ok := c.items[value.ID]; ok {
+		e.lastUsed = time.Now()
+		e.uses++
+		if e.heapIndex >= 0 {
+			heap.Fix(&c.evictable, e.heapIndex)
+		}
 		return
 	}
-	c.cache = append(c.cache, NewEntry(value))
+
+	e := newEntry(value)
+	c.items[value.ID] = e
+	heap.Push(&c.evictable, e)
 	c.totalSize += value.Size
 }
 
+// TotalSize returns the sum of the sizes of all images currently cached.
 func (c *Cache) TotalSize() int64 {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	return c.totalSize
 }
 
+// OverFilled returns whether the cache is over its configured maxSize.
 func (c *Cache) OverFilled() bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	return c.totalSize < c.maxSize
-}
-
-func (c *Cache) Evictable() (ea EntryByAge) {
-	for _, i := range c.cache {
-		if i.locked == false {
-			ea = append(ea, i)
-		}
-	}
-	sort.Sort(ea)
-	return ea
+	return c.totalSize > c.maxSize
 }
 
 // Len returns the number of items in the cache.
 func (c *Cache) Len() int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	return len(c.cache)
+	return len(c.items)
+}
+
+// Evict pops unlocked entries in eviction order (oldest/least-used
+// first), removing each from the backend, until either target bytes have
+// been freed or the cache has dropped below maxSize. It returns the
+// images that were evicted.
+func (c *Cache) Evict(ctx context.Context, target int64) []d.APIImages {
+	return c.evict(ctx, target, c.maxSize)
+}
+
+// evict is Evict with the size to stop at given as floor instead of
+// maxSize.
+func (c *Cache) evict(ctx context.Context, target, floor int64) []d.APIImages {
+	var freed int64
+	var evicted []d.APIImages
+
+	for {
+		c.mu.Lock()
+		if freed >= target || c.totalSize <= floor {
+			c.mu.Unlock()
+			break
+		}
+		if len(c.evictable) == 0 {
+			c.mu.Unlock()
+			break
+		}
+		e := heap.Pop(&c.evictable).(*entry)
+		delete(c.items, e.image.ID)
+		c.totalSize -= e.image.Size
+		c.mu.Unlock()
+
+		if c.backend != nil {
+			if err := c.backend.RemoveImage(ctx, e.image.ID); err != nil {
+				logrus.WithError(err).WithFields(logrus.Fields{"image_id": e.image.ID}).
+					Error("error evicting image from cache")
+				continue
+			}
+		}
+
+		freed += e.image.Size
+		evicted = append(evicted, e.image)
+	}
+
+	return evicted
+}
+
+// Run starts a background goroutine that evicts images whenever
+// TotalSize crosses the high watermark, driving it back down to the low
+// watermark. It returns a function that stops the goroutine.
+func (c *Cache) Run(ctx context.Context, interval time.Duration) func() {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.stop:
+				return
+			case <-ticker.C:
+				c.mu.Lock()
+				over := c.totalSize > c.highWatermark
+				low := c.lowWatermark
+				target := c.totalSize - low
+				c.mu.Unlock()
+				if over && target > 0 {
+					c.evict(ctx, target, low)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		c.once.Do(func() { close(c.stop) })
+	}
 }
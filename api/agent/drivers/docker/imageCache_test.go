@@ -0,0 +1,142 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	d "github.com/fsouza/go-dockerclient"
+)
+
+type noopBackend struct {
+	mu      sync.Mutex
+	removed []string
+}
+
+func (b *noopBackend) RemoveImage(ctx context.Context, id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removed = append(b.removed, id)
+	return nil
+}
+
+func image(i int, size int64) d.APIImages {
+	return d.APIImages{ID: fmt.Sprintf("img-%d", i), Size: size}
+}
+
+func TestCacheAddContainsRemove(t *testing.T) {
+	c := NewCache(1000, &noopBackend{})
+	img := image(1, 100)
+
+	if c.Contains(img) {
+		t.Fatal("cache should be empty")
+	}
+
+	c.Add(img)
+	if !c.Contains(img) {
+		t.Fatal("expected cache to contain image after Add")
+	}
+	if got := c.TotalSize(); got != 100 {
+		t.Fatalf("expected totalSize 100, got %d", got)
+	}
+
+	if err := c.Remove(img); err != nil {
+		t.Fatalf("unexpected error removing image: %v", err)
+	}
+	if c.Contains(img) {
+		t.Fatal("expected cache to not contain image after Remove")
+	}
+	if got := c.TotalSize(); got != 0 {
+		t.Fatalf("expected totalSize 0 after Remove, got %d", got)
+	}
+}
+
+func TestCacheOverFilled(t *testing.T) {
+	c := NewCache(150, &noopBackend{})
+	if c.OverFilled() {
+		t.Fatal("empty cache should not be over filled")
+	}
+	c.Add(image(1, 100))
+	if c.OverFilled() {
+		t.Fatal("cache under maxSize should not be over filled")
+	}
+	c.Add(image(2, 100))
+	if !c.OverFilled() {
+		t.Fatal("cache over maxSize should be over filled")
+	}
+}
+
+func TestCacheLockPreventsEviction(t *testing.T) {
+	backend := &noopBackend{}
+	c := NewCache(40, backend)
+
+	locked := image(1, 50)
+	unlocked := image(2, 50)
+	c.Add(locked)
+	c.Add(unlocked)
+
+	if err := c.Lock(locked.ID); err != nil {
+		t.Fatalf("unexpected error locking image: %v", err)
+	}
+
+	evicted := c.Evict(context.Background(), c.TotalSize())
+
+	if len(evicted) != 1 || evicted[0].ID != unlocked.ID {
+		t.Fatalf("expected only the unlocked image to be evicted, got %v", evicted)
+	}
+	if !c.Contains(locked) {
+		t.Fatal("locked image should not have been evicted")
+	}
+
+	c.Unlock(locked)
+	evicted = c.Evict(context.Background(), c.TotalSize())
+	if len(evicted) != 1 || evicted[0].ID != locked.ID {
+		t.Fatalf("expected the unlocked image to be evicted, got %v", evicted)
+	}
+}
+
+func TestCacheConcurrentAddMarkEvict(t *testing.T) {
+	const n = 200
+	backend := &noopBackend{}
+	c := NewCache(int64(n)*10/2, backend)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			img := image(i%20, 10)
+			c.Add(img)
+			c.Mark(img.ID)
+			if i%7 == 0 {
+				c.Evict(context.Background(), 10)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if c.TotalSize() < 0 {
+		t.Fatalf("totalSize went negative: %d", c.TotalSize())
+	}
+	if c.Len() > 20 {
+		t.Fatalf("expected at most 20 distinct images, got %d", c.Len())
+	}
+}
+
+func TestCacheEvictStopsAsSoonAsUnderMaxSize(t *testing.T) {
+	backend := &noopBackend{}
+	c := NewCache(10000, backend)
+
+	for i := 0; i < 5; i++ {
+		c.Add(image(i, 100))
+	}
+
+	evicted := c.Evict(context.Background(), 5000)
+	if len(evicted) != 0 {
+		t.Fatalf("expected Evict to stop immediately since the cache was never over maxSize, evicted %v", evicted)
+	}
+	if c.Len() != 5 {
+		t.Fatalf("expected all 5 images to remain cached, got %d", c.Len())
+	}
+}
@@ -0,0 +1,138 @@
+// Package errdefs defines the behavior interfaces that API error values
+// are expected to implement. Callers that need to map an error to an HTTP
+// status code (or any other external representation) should test against
+// these interfaces with the Is* helpers rather than comparing against
+// sentinel values or matching on error strings.
+package errdefs
+
+// ErrNotFound marks an error as meaning the requested resource does not
+// exist. It should map to HTTP 404.
+type ErrNotFound interface {
+	NotFound() bool
+}
+
+// ErrConflict marks an error as meaning the request conflicts with the
+// current state of a resource (e.g. it already exists). It should map to
+// HTTP 409.
+type ErrConflict interface {
+	Conflict() bool
+}
+
+// ErrInvalidParameter marks an error as meaning the caller supplied a bad
+// or malformed argument. It should map to HTTP 400.
+type ErrInvalidParameter interface {
+	InvalidParameter() bool
+}
+
+// ErrUnauthorized marks an error as meaning the caller did not
+// authenticate. It should map to HTTP 401.
+type ErrUnauthorized interface {
+	Unauthorized() bool
+}
+
+// ErrForbidden marks an error as meaning the caller authenticated but
+// isn't allowed to perform the operation. It should map to HTTP 403.
+type ErrForbidden interface {
+	Forbidden() bool
+}
+
+// ErrUnavailable marks an error as meaning the resource or service is
+// temporarily unavailable. It should map to HTTP 503.
+type ErrUnavailable interface {
+	Unavailable() bool
+}
+
+// ErrSystem marks an error as an internal/system failure, as opposed to
+// one caused by the caller's request. It should map to HTTP 500.
+type ErrSystem interface {
+	System() bool
+}
+
+// causer is implemented by github.com/pkg/errors wrapped errors.
+type causer interface {
+	Cause() error
+}
+
+// unwrapper is implemented by errors created with fmt.Errorf("...: %w").
+type unwrapper interface {
+	Unwrap() error
+}
+
+// getImplementer walks err's cause/unwrap chain looking for a value that
+// implements one of the interfaces above, returning the first one found.
+// If none is found, err itself is returned.
+func getImplementer(err error) error {
+	switch e := err.(type) {
+	case
+		ErrNotFound,
+		ErrConflict,
+		ErrInvalidParameter,
+		ErrUnauthorized,
+		ErrForbidden,
+		ErrUnavailable,
+		ErrSystem:
+		return err
+	case causer:
+		return getImplementer(e.Cause())
+	case unwrapper:
+		return getImplementer(e.Unwrap())
+	default:
+		return err
+	}
+}
+
+// IsNotFound returns whether the first error in err's cause/unwrap chain
+// that implements any errdefs interface implements ErrNotFound and reports
+// true from NotFound.
+func IsNotFound(err error) bool {
+	e, ok := getImplementer(err).(ErrNotFound)
+	return ok && e.NotFound()
+}
+
+// IsConflict returns whether the first error in err's cause/unwrap chain
+// that implements any errdefs interface implements ErrConflict and reports
+// true from Conflict.
+func IsConflict(err error) bool {
+	e, ok := getImplementer(err).(ErrConflict)
+	return ok && e.Conflict()
+}
+
+// IsInvalidParameter returns whether the first error in err's cause/unwrap
+// chain that implements any errdefs interface implements
+// ErrInvalidParameter and reports true from InvalidParameter.
+func IsInvalidParameter(err error) bool {
+	e, ok := getImplementer(err).(ErrInvalidParameter)
+	return ok && e.InvalidParameter()
+}
+
+// IsUnauthorized returns whether the first error in err's cause/unwrap
+// chain that implements any errdefs interface implements ErrUnauthorized
+// and reports true from Unauthorized.
+func IsUnauthorized(err error) bool {
+	e, ok := getImplementer(err).(ErrUnauthorized)
+	return ok && e.Unauthorized()
+}
+
+// IsForbidden returns whether the first error in err's cause/unwrap chain
+// that implements any errdefs interface implements ErrForbidden and reports
+// true from Forbidden.
+func IsForbidden(err error) bool {
+	e, ok := getImplementer(err).(ErrForbidden)
+	return ok && e.Forbidden()
+}
+
+// IsUnavailable returns whether the first error in err's cause/unwrap
+// chain that implements any errdefs interface implements ErrUnavailable
+// and reports true from Unavailable.
+func IsUnavailable(err error) bool {
+	e, ok := getImplementer(err).(ErrUnavailable)
+	return ok && e.Unavailable()
+}
+
+// IsSystem returns whether the first error in err's cause/unwrap chain
+// that implements any errdefs interface implements ErrSystem and reports
+// true from System.
+func IsSystem(err error) bool {
+	e, ok := getImplementer(err).(ErrSystem)
+	return ok && e.System()
+}
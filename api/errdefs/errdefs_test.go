@@ -0,0 +1,48 @@
+package errdefs
+
+import (
+	"fmt"
+	"testing"
+)
+
+type notFoundErr string
+
+func (e notFoundErr) Error() string  { return string(e) }
+func (e notFoundErr) NotFound() bool { return true }
+
+type causeWrapper struct {
+	msg   string
+	cause error
+}
+
+func (e causeWrapper) Error() string { return e.msg }
+func (e causeWrapper) Cause() error  { return e.cause }
+
+func TestIsNotFoundDirect(t *testing.T) {
+	if !IsNotFound(notFoundErr("nope")) {
+		t.Fatal("expected IsNotFound to be true for a direct ErrNotFound")
+	}
+	if IsNotFound(fmt.Errorf("plain error")) {
+		t.Fatal("expected IsNotFound to be false for a plain error")
+	}
+}
+
+func TestIsNotFoundThroughCause(t *testing.T) {
+	err := causeWrapper{msg: "wrapped", cause: notFoundErr("nope")}
+	if !IsNotFound(err) {
+		t.Fatal("expected IsNotFound to unwrap through Cause()")
+	}
+}
+
+func TestIsNotFoundThroughUnwrap(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", notFoundErr("nope"))
+	if !IsNotFound(err) {
+		t.Fatal("expected IsNotFound to unwrap through Unwrap()")
+	}
+}
+
+func TestIsNotFoundNil(t *testing.T) {
+	if IsNotFound(nil) {
+		t.Fatal("expected IsNotFound(nil) to be false")
+	}
+}
@@ -0,0 +1,5 @@
+package models
+
+// Config is a flat string map of arbitrary user-supplied configuration,
+// attached to Apps, Routes, Fns and Triggers.
+type Config map[string]string
@@ -0,0 +1,10 @@
+package models
+
+import "testing"
+
+func TestConfigIsAPlainStringMap(t *testing.T) {
+	cfg := Config{"FOO": "bar"}
+	if cfg["FOO"] != "bar" {
+		t.Fatalf("expected Config to behave as a plain map[string]string")
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when a cursor fails signature verification
+// or is otherwise malformed.
+var ErrInvalidCursor = invalidParameterErr("invalid cursor")
+
+// cursorSecret signs pagination cursors so clients cannot forge positions
+// across shards. It defaults to a fixed value for tests and single-node
+// deployments; operators should call SetCursorSecret at startup to use a
+// per-deployment secret.
+var cursorSecret = []byte("fn-default-cursor-secret")
+
+// SetCursorSecret overrides the secret used to sign and verify pagination
+// cursors. It must be called before the Datastore serves any requests.
+func SetCursorSecret(secret []byte) {
+	cursorSecret = secret
+}
+
+// EncodeCursor signs sortKey - the last row's sort key from a List call -
+// and returns an opaque cursor that's safe to hand back to clients.
+func EncodeCursor(sortKey string) string {
+	if sortKey == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, cursorSecret)
+	mac.Write([]byte(sortKey))
+	sig := mac.Sum(nil)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(sortKey)) + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// DecodeCursor verifies cursor's signature and returns the sort key it
+// encodes. It returns ErrInvalidCursor if the cursor was forged, malformed,
+// or signed with a different secret than the one currently configured.
+func DecodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+
+	parts := strings.SplitN(cursor, ".", 2)
+	if len(parts) != 2 {
+		return "", ErrInvalidCursor
+	}
+
+	sortKey, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+
+	mac := hmac.New(sha256.New, cursorSecret)
+	mac.Write(sortKey)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return "", ErrInvalidCursor
+	}
+
+	return string(sortKey), nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import "testing"
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := EncodeCursor("app_01ABC")
+
+	key, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("unexpected error decoding cursor: %v", err)
+	}
+	if key != "app_01ABC" {
+		t.Fatalf("expected sort key app_01ABC, got %q", key)
+	}
+}
+
+func TestCursorEmpty(t *testing.T) {
+	if cursor := EncodeCursor(""); cursor != "" {
+		t.Fatalf("expected empty cursor for empty sort key, got %q", cursor)
+	}
+	key, err := DecodeCursor("")
+	if err != nil || key != "" {
+		t.Fatalf("expected empty key and no error decoding empty cursor, got %q, %v", key, err)
+	}
+}
+
+func TestCursorTampered(t *testing.T) {
+	cursor := EncodeCursor("app_01ABC")
+	tampered := cursor[:len(cursor)-1] + "x"
+
+	if _, err := DecodeCursor(tampered); err != ErrInvalidCursor {
+		t.Fatalf("expected ErrInvalidCursor for tampered cursor, got %v", err)
+	}
+}
+
+func TestCursorDifferentSecret(t *testing.T) {
+	cursor := EncodeCursor("app_01ABC")
+
+	SetCursorSecret([]byte("a-different-secret"))
+	defer SetCursorSecret([]byte("fn-default-cursor-secret"))
+
+	if _, err := DecodeCursor(cursor); err != ErrInvalidCursor {
+		t.Fatalf("expected ErrInvalidCursor when secret changed, got %v", err)
+	}
+}
@@ -15,8 +15,9 @@ type Datastore interface {
 	// Returns ErrAppsNotFound if no app is found.
 	GetAppID(ctx context.Context, appName string) (string, error)
 
-	// GetApps gets a slice of Apps, optionally filtered by name, and a cursor.
-	// Missing filter or empty name will match all Apps.
+	// GetApps gets a page of Apps matching filter, up to filter.Limit() of them,
+	// along with a cursor to fetch the next page. Missing filter or empty NameContains
+	// and Labels will match all Apps.
 	GetApps(ctx context.Context, filter *AppFilter) (*AppList, error)
 
 	// InsertApp inserts an App. Returns ErrDatastoreEmptyApp when app is nil, and
@@ -27,6 +28,8 @@ type Datastore interface {
 	// UpdateApp updates an App's Config. Returns ErrDatastoreEmptyApp when app is nil, and
 	// ErrDatastoreEmptyAppName when app.Name is empty.
 	// Returns ErrAppsNotFound if an App is not found.
+	// Callers doing a read-modify-write should do the GetAppByID and the UpdateApp in the
+	// same RunInTransaction callback to avoid racing another writer.
 	UpdateApp(ctx context.Context, app *App) (*App, error)
 
 	// RemoveApp removes the App named appName. Returns ErrDatastoreEmptyAppName if appName is empty.
@@ -39,9 +42,10 @@ type Datastore interface {
 	// Returns ErrRoutesNotFound when no matching route is found.
 	GetRoute(ctx context.Context, appID, routePath string) (*Route, error)
 
-	// GetRoutesByApp gets a slice of routes for a appName, optionally filtering on filter (filter.AppName is ignored).
-	// Returns ErrDatastoreEmptyAppName if appName is empty.
-	GetRoutesByApp(ctx context.Context, appID string, filter *RouteFilter) ([]*Route, error)
+	// GetRoutesByApp gets a page of routes for appID, optionally filtering on filter (filter.AppName is
+	// ignored - the app is always scoped by the appID argument), along with a cursor to fetch the next page.
+	// Returns ErrDatastoreEmptyAppName if appID is empty.
+	GetRoutesByApp(ctx context.Context, appID string, filter *RouteFilter) (*RouteList, error)
 
 	// InsertRoute inserts a route. Returns ErrDatastoreEmptyRoute when route is nil, and ErrDatastoreEmptyAppName
 	// or ErrDatastoreEmptyRoutePath for empty AppName or Path.
@@ -63,7 +67,7 @@ type Datastore interface {
 	// ErrMissingName is func.Name is empty.
 	UpdateFn(ctx context.Context, fn *Fn) (*Fn, error)
 
-	// GetFns returns a list of funcs, and a cursor, applying any additional filters provided.
+	// GetFns returns a page of funcs, and a cursor to fetch the next page, applying any additional filters provided.
 	GetFns(ctx context.Context, filter *FnFilter) (*FnList, error)
 
 	// GetFnByID returns a function by ID. Returns ErrDatastoreEmptyFnID if fnID is empty.
@@ -89,13 +93,26 @@ type Datastore interface {
 	// Returns ErrTriggerNotFound when no matching trigger is found
 	GetTriggerByID(ctx context.Context, triggerID string) (*Trigger, error)
 
-	// GetTriggers gets a list of triggers that match the specified filter
-	// Return ErrDatastoreEmptyAppId if no AppID set in the filter
+	// GetTriggers gets a page of triggers that match the specified filter, and a cursor to fetch the next page.
+	// Return ErrDatastoreEmptyAppID if no AppID set in the filter
 	GetTriggers(ctx context.Context, filter *TriggerFilter) (*TriggerList, error)
 
 	// GetTriggerBySource loads a trigger by type and source ID - this is only needed when the data store is also used for agent read access
 	GetTriggerBySource(ctx context.Context, appId string, triggerType, source string) (*Trigger, error)
 
+	// GetTriggersByCEType loads every Trigger in appID subscribed to CloudEvents of the given
+	// type, for the triggers/cloudevents dispatcher to route an incoming event to.
+	GetTriggersByCEType(ctx context.Context, appID, ceType string) ([]*Trigger, error)
+
+	// RunInTransaction calls fn with a DatastoreTx scoped to a single transaction, so
+	// multi-object mutations (e.g. creating an App along with its Fns and Triggers) are
+	// atomic. The transaction commits if fn returns nil and rolls back otherwise - including
+	// when fn panics, in which case the panic is repropagated after rollback.
+	// SQL-backed Datastores retry fn on serialization failures (Postgres 40001, SQLite
+	// SQLITE_BUSY); the in-memory Datastore runs fn against a copy-on-write snapshot.
+	// tx must not be retained past fn's return.
+	RunInTransaction(ctx context.Context, fn func(tx DatastoreTx) error) error
+
 	// implements io.Closer to shutdown
 	io.Closer
 }
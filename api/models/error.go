@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// err is a plain error annotated with one of the errdefs behavior
+// interfaces, so server.handleErrorResponse can map it to the right HTTP
+// status without string matching or comparing against sentinel values
+// directly.
+type err struct {
+	error
+	notFound         bool
+	conflict         bool
+	invalidParameter bool
+}
+
+func (e err) NotFound() bool         { return e.notFound }
+func (e err) Conflict() bool         { return e.conflict }
+func (e err) InvalidParameter() bool { return e.invalidParameter }
+
+// Unwrap exposes the wrapped cause (if any) to errors.Is/errors.As, so a
+// Wrap* error still compares equal to the driver error it annotates.
+func (e err) Unwrap() error { return e.error }
+
+func notFoundErr(msg string) error { return err{error: errors.New(msg), notFound: true} }
+func conflictErr(msg string) error { return err{error: errors.New(msg), conflict: true} }
+func invalidParameterErr(msg string) error {
+	return err{error: errors.New(msg), invalidParameter: true}
+}
+
+// WrapNotFound annotates cause with msg and classifies the result as
+// errdefs.ErrNotFound, so a Datastore can report e.g. a row-not-found
+// from the underlying driver with its own context instead of returning
+// a bare ErrAppsNotFound-style sentinel.
+func WrapNotFound(cause error, msg string) error {
+	return err{error: pkgerrors.Wrap(cause, msg), notFound: true}
+}
+
+// WrapConflict annotates cause with msg and classifies the result as
+// errdefs.ErrConflict.
+func WrapConflict(cause error, msg string) error {
+	return err{error: pkgerrors.Wrap(cause, msg), conflict: true}
+}
+
+// WrapInvalidParameter annotates cause with msg and classifies the
+// result as errdefs.ErrInvalidParameter.
+func WrapInvalidParameter(cause error, msg string) error {
+	return err{error: pkgerrors.Wrap(cause, msg), invalidParameter: true}
+}
+
+// App errors.
+var (
+	ErrAppsNotFound      = notFoundErr("app not found")
+	ErrAppsAlreadyExists = conflictErr("app already exists")
+
+	ErrDatastoreEmptyApp     = invalidParameterErr("missing app")
+	ErrDatastoreEmptyAppName = invalidParameterErr("missing app name")
+	ErrDatastoreEmptyAppID   = invalidParameterErr("missing app ID")
+)
+
+// Route errors.
+var (
+	ErrRoutesNotFound      = notFoundErr("route not found")
+	ErrRoutesAlreadyExists = conflictErr("route already exists")
+
+	ErrDatastoreEmptyRoute     = invalidParameterErr("missing route")
+	ErrDatastoreEmptyRoutePath = invalidParameterErr("missing route path")
+)
+
+// Fn errors.
+var (
+	ErrFnsNotFound = notFoundErr("fn not found")
+	ErrMissingName = invalidParameterErr("missing name")
+
+	ErrDatastoreEmptyFnID = invalidParameterErr("missing fn ID")
+)
+
+// Trigger errors.
+var (
+	ErrTriggerNotFound      = notFoundErr("trigger not found")
+	ErrTriggerAlreadyExists = conflictErr("trigger already exists")
+
+	ErrDatastoreEmptyTrigger = invalidParameterErr("missing trigger")
+)
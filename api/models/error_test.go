@@ -0,0 +1,28 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrapNotFoundClassifiesAndPreservesCause(t *testing.T) {
+	cause := errors.New("no rows")
+	wrapped := WrapNotFound(cause, "app app_01ABC")
+
+	e, ok := wrapped.(err)
+	if !ok || !e.NotFound() {
+		t.Fatalf("expected WrapNotFound to classify as NotFound, got %v", wrapped)
+	}
+	if !errors.Is(wrapped, cause) {
+		t.Fatalf("expected WrapNotFound to preserve cause in the unwrap chain")
+	}
+}
+
+func TestWrapConflictAndInvalidParameter(t *testing.T) {
+	if e, ok := WrapConflict(errors.New("dup"), "app").(err); !ok || !e.Conflict() {
+		t.Fatalf("expected WrapConflict to classify as Conflict")
+	}
+	if e, ok := WrapInvalidParameter(errors.New("bad"), "app").(err); !ok || !e.InvalidParameter() {
+		t.Fatalf("expected WrapInvalidParameter to classify as InvalidParameter")
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+// MaxPerPage is the hard cap on PageFilter.PerPage. Every Datastore
+// implementation must enforce it regardless of what a caller requests.
+const MaxPerPage = 100
+
+// DefaultPerPage is used when a filter's PerPage is unset or non-positive.
+const DefaultPerPage = 30
+
+// PageFilter holds the fields shared by every List filter: pagination,
+// substring name matching and label selection. Concrete filters embed it.
+type PageFilter struct {
+	// PerPage caps the number of items returned. Values over MaxPerPage
+	// are clamped to it; use Limit to read the effective value.
+	PerPage int `json:"per_page,omitempty"`
+
+	// Cursor continues a previous List call. It's an opaque, HMAC-signed
+	// token produced by EncodeCursor - Datastore implementations must
+	// treat it as a black box, verifying it with DecodeCursor before
+	// using the sort key it carries.
+	Cursor string `json:"cursor,omitempty"`
+
+	// NameContains, when set, restricts results to names containing this
+	// substring.
+	NameContains string `json:"name_contains,omitempty"`
+
+	// Labels restricts results to resources matching every requirement
+	// in the selector, using Kubernetes selector syntax
+	// (`key=value`, `key in (a,b)`, `!key`). Parse with
+	// ParseLabelSelector before evaluating it against a resource.
+	Labels string `json:"labels,omitempty"`
+}
+
+// Limit returns PerPage clamped to (0, MaxPerPage], defaulting to
+// DefaultPerPage when PerPage is unset.
+func (f PageFilter) Limit() int {
+	switch {
+	case f.PerPage <= 0:
+		return DefaultPerPage
+	case f.PerPage > MaxPerPage:
+		return MaxPerPage
+	default:
+		return f.PerPage
+	}
+}
+
+// AppFilter filters the results of Datastore.GetApps.
+type AppFilter struct {
+	PageFilter
+}
+
+// RouteFilter filters the results of Datastore.GetRoutesByApp. AppName is
+// ignored by GetRoutesByApp, which always scopes to the appID argument.
+type RouteFilter struct {
+	PageFilter
+	AppName string `json:"app_name,omitempty"`
+}
+
+// FnFilter filters the results of Datastore.GetFns.
+type FnFilter struct {
+	PageFilter
+	AppID string `json:"app_id,omitempty"`
+}
+
+// TriggerFilter filters the results of Datastore.GetTriggers.
+type TriggerFilter struct {
+	PageFilter
+	AppID string `json:"app_id,omitempty"`
+	FnID  string `json:"fn_id,omitempty"`
+}
+
+// AppList is a page of Apps, with the cursor to fetch the next page.
+type AppList struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	Items      []*App `json:"items"`
+}
+
+// RouteList is a page of Routes, with the cursor to fetch the next page.
+type RouteList struct {
+	NextCursor string   `json:"next_cursor,omitempty"`
+	Items      []*Route `json:"items"`
+}
+
+// FnList is a page of Fns, with the cursor to fetch the next page.
+type FnList struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	Items      []*Fn  `json:"items"`
+}
+
+// TriggerList is a page of Triggers, with the cursor to fetch the next page.
+type TriggerList struct {
+	NextCursor string     `json:"next_cursor,omitempty"`
+	Items      []*Trigger `json:"items"`
+}
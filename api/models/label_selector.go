@@ -0,0 +1,142 @@
+package models
+
+import "strings"
+
+// LabelOperator is the comparison a LabelRequirement applies.
+type LabelOperator string
+
+const (
+	LabelEquals       LabelOperator = "="
+	LabelIn           LabelOperator = "in"
+	LabelNotIn        LabelOperator = "notin"
+	LabelExists       LabelOperator = "exists"
+	LabelDoesNotExist LabelOperator = "!"
+)
+
+// LabelRequirement is a single label-selector requirement.
+type LabelRequirement struct {
+	Key      string
+	Operator LabelOperator
+	Values   []string
+}
+
+// Matches reports whether labels satisfies this requirement.
+func (r LabelRequirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case LabelExists:
+		return ok
+	case LabelDoesNotExist:
+		return !ok
+	case LabelEquals:
+		return ok && value == r.Values[0]
+	case LabelIn:
+		if !ok {
+			return false
+		}
+		for _, v := range r.Values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	case LabelNotIn:
+		if !ok {
+			return true
+		}
+		for _, v := range r.Values {
+			if v == value {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseLabelSelector parses a comma-separated label selector expression
+// using Kubernetes selector syntax, e.g. "env=prod,tier in (web,api),!deprecated".
+// An empty selector parses to a nil, always-matching requirement set.
+func ParseLabelSelector(selector string) ([]LabelRequirement, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return nil, nil
+	}
+
+	var reqs []LabelRequirement
+	for _, term := range splitSelectorTerms(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		req, err := parseRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
+
+// splitSelectorTerms splits on top-level commas, ignoring commas nested
+// inside a `(...)` value list such as "tier in (web,api)".
+func splitSelectorTerms(selector string) []string {
+	var terms []string
+	depth := 0
+	start := 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	terms = append(terms, selector[start:])
+	return terms
+}
+
+func parseRequirement(term string) (LabelRequirement, error) {
+	switch {
+	case strings.HasPrefix(term, "!"):
+		return LabelRequirement{Key: strings.TrimSpace(term[1:]), Operator: LabelDoesNotExist}, nil
+
+	case strings.Contains(term, " in ") || strings.Contains(term, " notin "):
+		op := LabelIn
+		sep := " in "
+		if strings.Contains(term, " notin ") {
+			op = LabelNotIn
+			sep = " notin "
+		}
+		parts := strings.SplitN(term, sep, 2)
+		if len(parts) != 2 {
+			return LabelRequirement{}, invalidParameterErr("malformed label selector term: " + term)
+		}
+		values := strings.TrimSpace(parts[1])
+		values = strings.TrimPrefix(values, "(")
+		values = strings.TrimSuffix(values, ")")
+		var vs []string
+		for _, v := range strings.Split(values, ",") {
+			vs = append(vs, strings.TrimSpace(v))
+		}
+		return LabelRequirement{Key: strings.TrimSpace(parts[0]), Operator: op, Values: vs}, nil
+
+	case strings.Contains(term, "="):
+		parts := strings.SplitN(term, "=", 2)
+		return LabelRequirement{
+			Key:      strings.TrimSpace(parts[0]),
+			Operator: LabelEquals,
+			Values:   []string{strings.TrimSpace(parts[1])},
+		}, nil
+
+	default:
+		return LabelRequirement{Key: strings.TrimSpace(term), Operator: LabelExists}, nil
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestParseLabelSelector(t *testing.T) {
+	reqs, err := ParseLabelSelector("env=prod,tier in (web,api),!deprecated,exists-only")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reqs) != 4 {
+		t.Fatalf("expected 4 requirements, got %d: %+v", len(reqs), reqs)
+	}
+
+	labels := map[string]string{"env": "prod", "tier": "web", "exists-only": ""}
+	for _, r := range reqs {
+		if !r.Matches(labels) {
+			t.Fatalf("expected requirement %+v to match %v", r, labels)
+		}
+	}
+
+	labels["tier"] = "batch"
+	if reqs[1].Matches(labels) {
+		t.Fatalf("expected tier=batch to not match 'tier in (web,api)'")
+	}
+}
+
+func TestParseLabelSelectorEmpty(t *testing.T) {
+	reqs, err := ParseLabelSelector("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reqs != nil {
+		t.Fatalf("expected nil requirements for empty selector, got %+v", reqs)
+	}
+}
+
+func TestLabelRequirementNotIn(t *testing.T) {
+	reqs, err := ParseLabelSelector("tier notin (web,api)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reqs[0].Matches(map[string]string{"tier": "batch"}) {
+		t.Fatal("expected tier=batch to match 'tier notin (web,api)'")
+	}
+	if reqs[0].Matches(map[string]string{"tier": "web"}) {
+		t.Fatal("expected tier=web to not match 'tier notin (web,api)'")
+	}
+}
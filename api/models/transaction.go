@@ -0,0 +1,36 @@
+package models
+
+import "context"
+
+// DatastoreTx is the view of a Datastore available inside a
+// RunInTransaction callback: the same CRUD surface as Datastore, scoped
+// to a single transaction. A DatastoreTx must not be retained past the
+// callback that received it.
+type DatastoreTx interface {
+	GetAppByID(ctx context.Context, appID string) (*App, error)
+	GetAppID(ctx context.Context, appName string) (string, error)
+	GetApps(ctx context.Context, filter *AppFilter) (*AppList, error)
+	InsertApp(ctx context.Context, app *App) (*App, error)
+	UpdateApp(ctx context.Context, app *App) (*App, error)
+	RemoveApp(ctx context.Context, appID string) error
+
+	GetRoute(ctx context.Context, appID, routePath string) (*Route, error)
+	GetRoutesByApp(ctx context.Context, appID string, filter *RouteFilter) (*RouteList, error)
+	InsertRoute(ctx context.Context, route *Route) (*Route, error)
+	UpdateRoute(ctx context.Context, route *Route) (*Route, error)
+	RemoveRoute(ctx context.Context, appID, routePath string) error
+
+	InsertFn(ctx context.Context, fn *Fn) (*Fn, error)
+	UpdateFn(ctx context.Context, fn *Fn) (*Fn, error)
+	GetFns(ctx context.Context, filter *FnFilter) (*FnList, error)
+	GetFnByID(ctx context.Context, fnID string) (*Fn, error)
+	RemoveFn(ctx context.Context, fnID string) error
+
+	InsertTrigger(ctx context.Context, trigger *Trigger) (*Trigger, error)
+	UpdateTrigger(ctx context.Context, trigger *Trigger) (*Trigger, error)
+	RemoveTrigger(ctx context.Context, triggerID string) error
+	GetTriggerByID(ctx context.Context, triggerID string) (*Trigger, error)
+	GetTriggers(ctx context.Context, filter *TriggerFilter) (*TriggerList, error)
+	GetTriggerBySource(ctx context.Context, appId string, triggerType, source string) (*Trigger, error)
+	GetTriggersByCEType(ctx context.Context, appID, ceType string) ([]*Trigger, error)
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"gitlab-odx.oracle.com/odx/functions/api/models"
@@ -10,7 +11,15 @@ import (
 func (s *Server) handleAppList(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	filter := &models.AppFilter{}
+	perPage, _ := strconv.Atoi(c.Query("per_page"))
+	filter := &models.AppFilter{
+		PageFilter: models.PageFilter{
+			PerPage:      perPage,
+			Cursor:       c.Query("cursor"),
+			NameContains: c.Query("name_contains"),
+			Labels:       c.Query("labels"),
+		},
+	}
 
 	apps, err := s.Datastore.GetApps(ctx, filter)
 	if err != nil {
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gitlab-odx.oracle.com/odx/functions/api/errdefs"
+)
+
+// simpleError is the JSON body written for any handler error.
+type simpleError struct {
+	Error *simpleErrorDetail `json:"error,omitempty"`
+}
+
+type simpleErrorDetail struct {
+	Message string `json:"message"`
+}
+
+// handleErrorResponse maps err to an HTTP status code by checking which
+// errdefs behavior interface it implements, then writes the error as a
+// JSON body. Errors that implement none of the errdefs interfaces are
+// treated as internal/system errors.
+func handleErrorResponse(c *gin.Context, err error) {
+	status := statusCode(err)
+	if status >= http.StatusInternalServerError {
+		logrus.WithError(err).Error("api error")
+	}
+
+	c.JSON(status, simpleError{&simpleErrorDetail{Message: err.Error()}})
+}
+
+func statusCode(err error) int {
+	switch {
+	case errdefs.IsNotFound(err):
+		return http.StatusNotFound
+	case errdefs.IsConflict(err):
+		return http.StatusConflict
+	case errdefs.IsInvalidParameter(err):
+		return http.StatusBadRequest
+	case errdefs.IsUnauthorized(err):
+		return http.StatusUnauthorized
+	case errdefs.IsForbidden(err):
+		return http.StatusForbidden
+	case errdefs.IsUnavailable(err):
+		return http.StatusServiceUnavailable
+	case errdefs.IsSystem(err):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gitlab-odx.oracle.com/odx/functions/api/models"
+)
+
+func (s *Server) handleFnList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	perPage, _ := strconv.Atoi(c.Query("per_page"))
+	filter := &models.FnFilter{
+		PageFilter: models.PageFilter{
+			PerPage:      perPage,
+			Cursor:       c.Query("cursor"),
+			NameContains: c.Query("name_contains"),
+			Labels:       c.Query("labels"),
+		},
+		AppID: c.Query("app_id"),
+	}
+
+	fns, err := s.Datastore.GetFns(ctx, filter)
+	if err != nil {
+		handleErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fnsResponse{"Successfully listed functions", fns})
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gitlab-odx.oracle.com/odx/functions/api/models"
+)
+
+func (s *Server) handleRouteList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	appID := c.Param("appID")
+
+	perPage, _ := strconv.Atoi(c.Query("per_page"))
+	filter := &models.RouteFilter{
+		PageFilter: models.PageFilter{
+			PerPage:      perPage,
+			Cursor:       c.Query("cursor"),
+			NameContains: c.Query("name_contains"),
+			Labels:       c.Query("labels"),
+		},
+	}
+
+	routes, err := s.Datastore.GetRoutesByApp(ctx, appID, filter)
+	if err != nil {
+		handleErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, routesResponse{"Successfully listed routes", routes})
+}
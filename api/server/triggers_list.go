@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gitlab-odx.oracle.com/odx/functions/api/models"
+)
+
+func (s *Server) handleTriggerList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	perPage, _ := strconv.Atoi(c.Query("per_page"))
+	filter := &models.TriggerFilter{
+		PageFilter: models.PageFilter{
+			PerPage:      perPage,
+			Cursor:       c.Query("cursor"),
+			NameContains: c.Query("name_contains"),
+			Labels:       c.Query("labels"),
+		},
+		AppID: c.Query("app_id"),
+		FnID:  c.Query("fn_id"),
+	}
+
+	triggers, err := s.Datastore.GetTriggers(ctx, filter)
+	if err != nil {
+		handleErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, triggersResponse{"Successfully listed triggers", triggers})
+}
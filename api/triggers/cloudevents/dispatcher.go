@@ -0,0 +1,97 @@
+package cloudevents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"gitlab-odx.oracle.com/odx/functions/api/models"
+)
+
+// Invoker calls a function, forwarding the event in CloudEvents binary
+// mode, and returns the function's raw response so Dispatcher can decide
+// whether it carries a reply CloudEvent.
+type Invoker interface {
+	Invoke(ctx context.Context, fnID string, headers http.Header, body []byte) (respHeaders http.Header, respBody []byte, err error)
+}
+
+// TriggerSource looks up the Triggers that should receive a CloudEvent.
+// It's the subset of models.Datastore the Dispatcher needs.
+type TriggerSource interface {
+	GetTriggersByCEType(ctx context.Context, appID, ceType string) ([]*models.Trigger, error)
+}
+
+// Dispatcher routes incoming CloudEvents to the Triggers registered for
+// their type, invoking the matching functions in CloudEvents binary mode
+// and dead-lettering any delivery that fails.
+type Dispatcher struct {
+	Triggers   TriggerSource
+	Invoker    Invoker
+	DeadLetter EventSink
+}
+
+// NewDispatcher returns a Dispatcher that looks up triggers via triggers,
+// invokes functions via invoker, and dead-letters failed deliveries to
+// deadLetter (which may be nil to drop them on failure).
+func NewDispatcher(triggers TriggerSource, invoker Invoker, deadLetter EventSink) *Dispatcher {
+	return &Dispatcher{Triggers: triggers, Invoker: invoker, DeadLetter: deadLetter}
+}
+
+// Dispatch routes ev to every Trigger registered for appID and ev.Type
+// whose filter (Trigger.Config["filter"]) matches ev, invoking the
+// target function in binary mode. It returns one reply Event per
+// matching trigger whose function response was itself a CloudEvent.
+func (d *Dispatcher) Dispatch(ctx context.Context, appID string, ev *Event) ([]*Event, error) {
+	triggers, err := d.Triggers.GetTriggersByCEType(ctx, appID, ev.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var replies []*Event
+	for _, trigger := range triggers {
+		filter, err := ParseFilter([]byte(trigger.Config["filter"]))
+		if err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{"trigger_id": trigger.ID}).
+				Error("cloudevents: skipping trigger with malformed filter")
+			continue
+		}
+		if !filter.Matches(ev) {
+			continue
+		}
+
+		reply, err := d.invoke(ctx, trigger, ev)
+		if err == nil {
+			if reply != nil {
+				replies = append(replies, reply)
+			}
+			continue
+		}
+
+		if d.DeadLetter == nil {
+			continue
+		}
+		if dlErr := d.DeadLetter.Send(ctx, ev); dlErr != nil {
+			return replies, fmt.Errorf("cloudevents: dispatch to trigger %s failed (%v) and dead-letter failed (%v)", trigger.ID, err, dlErr)
+		}
+	}
+	return replies, nil
+}
+
+// invoke calls the function behind trigger with ev in binary mode, and
+// interprets its response as a reply CloudEvent when possible.
+func (d *Dispatcher) invoke(ctx context.Context, trigger *models.Trigger, ev *Event) (*Event, error) {
+	respHeaders, respBody, err := d.Invoker.Invoke(ctx, trigger.FnID, ev.BinaryHeaders(), ev.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case respHeaders.Get("Content-Type") == ContentType:
+		return ParseStructured(respBody)
+	case respHeaders.Get("Ce-Id") != "":
+		return ParseBinary(respHeaders, respBody)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,137 @@
+package cloudevents
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitlab-odx.oracle.com/odx/functions/api/models"
+)
+
+type fakeTriggerSource []*models.Trigger
+
+func (f fakeTriggerSource) GetTriggersByCEType(ctx context.Context, appID, ceType string) ([]*models.Trigger, error) {
+	return f, nil
+}
+
+type fakeInvoker func(ctx context.Context, fnID string, headers http.Header, body []byte) (http.Header, []byte, error)
+
+func (f fakeInvoker) Invoke(ctx context.Context, fnID string, headers http.Header, body []byte) (http.Header, []byte, error) {
+	return f(ctx, fnID, headers, body)
+}
+
+type fakeSink struct {
+	sent []*Event
+	err  error
+}
+
+func (s *fakeSink) Send(ctx context.Context, e *Event) error {
+	s.sent = append(s.sent, e)
+	return s.err
+}
+
+func testEvent(typ string) *Event {
+	return &Event{ID: "1", Source: "/x", SpecVersion: SpecVersion, Type: typ}
+}
+
+func TestDispatchInvokesMatchingTriggers(t *testing.T) {
+	triggers := fakeTriggerSource{
+		{ID: "t1", FnID: "fn1"},
+	}
+	invoked := false
+	invoker := fakeInvoker(func(ctx context.Context, fnID string, headers http.Header, body []byte) (http.Header, []byte, error) {
+		invoked = true
+		if fnID != "fn1" {
+			t.Fatalf("expected to invoke fn1, got %s", fnID)
+		}
+		return http.Header{}, nil, nil
+	})
+
+	d := NewDispatcher(triggers, invoker, nil)
+	if _, err := d.Dispatch(context.Background(), "app1", testEvent("com.example.a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !invoked {
+		t.Fatal("expected the matching trigger's function to be invoked")
+	}
+}
+
+func TestDispatchSkipsNonMatchingFilter(t *testing.T) {
+	triggers := fakeTriggerSource{
+		{ID: "t1", FnID: "fn1", Config: models.Config{"filter": `{"exact":{"type":"com.example.b"}}`}},
+	}
+	invoker := fakeInvoker(func(ctx context.Context, fnID string, headers http.Header, body []byte) (http.Header, []byte, error) {
+		t.Fatal("expected no invocation for a non-matching filter")
+		return nil, nil, nil
+	})
+
+	d := NewDispatcher(triggers, invoker, nil)
+	if _, err := d.Dispatch(context.Background(), "app1", testEvent("com.example.a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDispatchSkipsMalformedFilterButContinues(t *testing.T) {
+	triggers := fakeTriggerSource{
+		{ID: "bad", FnID: "fn-bad", Config: models.Config{"filter": `{not valid json`}},
+		{ID: "good", FnID: "fn-good"},
+	}
+	invokedGood := false
+	invoker := fakeInvoker(func(ctx context.Context, fnID string, headers http.Header, body []byte) (http.Header, []byte, error) {
+		if fnID == "fn-bad" {
+			t.Fatal("expected the trigger with a malformed filter to be skipped, not invoked")
+		}
+		invokedGood = true
+		return http.Header{}, nil, nil
+	})
+
+	d := NewDispatcher(triggers, invoker, nil)
+	if _, err := d.Dispatch(context.Background(), "app1", testEvent("com.example.a")); err != nil {
+		t.Fatalf("expected the malformed trigger to be skipped rather than aborting dispatch, got error: %v", err)
+	}
+	if !invokedGood {
+		t.Fatal("expected the trigger after the malformed one to still be invoked")
+	}
+}
+
+func TestDispatchDeadLettersFailedInvocation(t *testing.T) {
+	triggers := fakeTriggerSource{
+		{ID: "t1", FnID: "fn1"},
+	}
+	invoker := fakeInvoker(func(ctx context.Context, fnID string, headers http.Header, body []byte) (http.Header, []byte, error) {
+		return nil, nil, errors.New("invoke failed")
+	})
+	sink := &fakeSink{}
+
+	d := NewDispatcher(triggers, invoker, sink)
+	if _, err := d.Dispatch(context.Background(), "app1", testEvent("com.example.a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sink.sent) != 1 {
+		t.Fatalf("expected the failed invocation to be dead-lettered, got %d sends", len(sink.sent))
+	}
+}
+
+func TestDispatchReturnsReplyEvent(t *testing.T) {
+	triggers := fakeTriggerSource{
+		{ID: "t1", FnID: "fn1"},
+	}
+	invoker := fakeInvoker(func(ctx context.Context, fnID string, headers http.Header, body []byte) (http.Header, []byte, error) {
+		h := http.Header{}
+		h.Set("Ce-Id", "2")
+		h.Set("Ce-Source", "/fn1")
+		h.Set("Ce-Specversion", SpecVersion)
+		h.Set("Ce-Type", "com.example.reply")
+		return h, nil, nil
+	})
+
+	d := NewDispatcher(triggers, invoker, nil)
+	replies, err := d.Dispatch(context.Background(), "app1", testEvent("com.example.a"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(replies) != 1 || replies[0].Type != "com.example.reply" {
+		t.Fatalf("expected one reply event, got %+v", replies)
+	}
+}
@@ -0,0 +1,261 @@
+// Package cloudevents accepts CloudEvents v1.0 (https://cloudevents.io)
+// over HTTP, in both structured and binary mode, and dispatches them to
+// the Triggers subscribed to their type and source.
+package cloudevents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// SpecVersion is the only CloudEvents spec version this package understands.
+const SpecVersion = "1.0"
+
+// ContentType is the Content-Type that marks a structured-mode request or
+// response body as a CloudEvent.
+const ContentType = "application/cloudevents+json"
+
+// Event is a CloudEvents v1.0 envelope: the required context attributes,
+// the optional ones this package cares about, and the event payload.
+type Event struct {
+	ID              string
+	Source          string
+	SpecVersion     string
+	Type            string
+	DataContentType string
+	Subject         string
+	Time            string
+	Extensions      map[string]string
+	Data            []byte
+}
+
+// validationError marks an Event as failing CloudEvents validation, so
+// server.handleErrorResponse (via api/errdefs) maps it to HTTP 400.
+type validationError string
+
+func (e validationError) Error() string          { return string(e) }
+func (e validationError) InvalidParameter() bool { return true }
+
+// Validate checks that the required CloudEvents attributes - id, source,
+// specversion and type - are present and that specversion is supported.
+func (e *Event) Validate() error {
+	switch {
+	case e.ID == "":
+		return validationError("cloudevents: missing required attribute \"id\"")
+	case e.Source == "":
+		return validationError("cloudevents: missing required attribute \"source\"")
+	case e.SpecVersion == "":
+		return validationError("cloudevents: missing required attribute \"specversion\"")
+	case e.Type == "":
+		return validationError("cloudevents: missing required attribute \"type\"")
+	case e.SpecVersion != SpecVersion:
+		return validationError("cloudevents: unsupported specversion " + e.SpecVersion)
+	}
+	return nil
+}
+
+// Attribute returns the value of one of Event's context attributes, or an
+// extension attribute, by name. It returns "" if name isn't set.
+func (e *Event) Attribute(name string) string {
+	switch name {
+	case "id":
+		return e.ID
+	case "source":
+		return e.Source
+	case "specversion":
+		return e.SpecVersion
+	case "type":
+		return e.Type
+	case "datacontenttype":
+		return e.DataContentType
+	case "subject":
+		return e.Subject
+	case "time":
+		return e.Time
+	default:
+		return e.Extensions[name]
+	}
+}
+
+// coreAttributes and their corresponding ce- binary mode header names.
+var coreAttributes = map[string]string{
+	"id":          "Ce-Id",
+	"source":      "Ce-Source",
+	"specversion": "Ce-Specversion",
+	"type":        "Ce-Type",
+	"subject":     "Ce-Subject",
+	"time":        "Ce-Time",
+}
+
+// ParseBinary builds an Event from a binary-mode request: context
+// attributes come from ce-* headers, datacontenttype from Content-Type,
+// and body is the event data verbatim.
+func ParseBinary(header http.Header, body []byte) (*Event, error) {
+	e := &Event{
+		ID:              header.Get("Ce-Id"),
+		Source:          header.Get("Ce-Source"),
+		SpecVersion:     header.Get("Ce-Specversion"),
+		Type:            header.Get("Ce-Type"),
+		Subject:         header.Get("Ce-Subject"),
+		Time:            header.Get("Ce-Time"),
+		DataContentType: header.Get("Content-Type"),
+		Data:            body,
+	}
+
+	for k := range header {
+		lower := strings.ToLower(k)
+		if !strings.HasPrefix(lower, "ce-") {
+			continue
+		}
+		name := strings.TrimPrefix(lower, "ce-")
+		if _, isCore := coreAttributes[name]; isCore {
+			continue
+		}
+		if e.Extensions == nil {
+			e.Extensions = make(map[string]string)
+		}
+		e.Extensions[name] = header.Get(k)
+	}
+
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// BinaryHeaders renders e as the ce-* headers and Content-Type a binary
+// mode request/response carries, so a dispatcher can re-emit it to a
+// function or sink without round-tripping through structured JSON.
+func (e *Event) BinaryHeaders() http.Header {
+	h := http.Header{}
+	h.Set("Ce-Id", e.ID)
+	h.Set("Ce-Source", e.Source)
+	h.Set("Ce-Specversion", e.SpecVersion)
+	h.Set("Ce-Type", e.Type)
+	if e.Subject != "" {
+		h.Set("Ce-Subject", e.Subject)
+	}
+	if e.Time != "" {
+		h.Set("Ce-Time", e.Time)
+	}
+	if e.DataContentType != "" {
+		h.Set("Content-Type", e.DataContentType)
+	}
+	for k, v := range e.Extensions {
+		h.Set("Ce-"+k, v)
+	}
+	return h
+}
+
+// structuredEvent is the JSON shape of a structured-mode CloudEvent.
+type structuredEvent struct {
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	SpecVersion     string            `json:"specversion"`
+	Type            string            `json:"type"`
+	DataContentType string            `json:"datacontenttype,omitempty"`
+	Subject         string            `json:"subject,omitempty"`
+	Time            string            `json:"time,omitempty"`
+	Data            json.RawMessage   `json:"data,omitempty"`
+	DataBase64      string            `json:"data_base64,omitempty"`
+	Extensions      map[string]string `json:"-"`
+}
+
+// knownStructuredFields are the CloudEvents context attributes handled
+// explicitly by ParseStructured; anything else is an extension attribute.
+var knownStructuredFields = map[string]bool{
+	"id": true, "source": true, "specversion": true, "type": true,
+	"datacontenttype": true, "subject": true, "time": true,
+	"data": true, "data_base64": true,
+}
+
+// ParseStructured builds an Event from a structured-mode request body
+// (Content-Type: application/cloudevents+json): every CloudEvents
+// attribute, including extensions, is a top-level JSON field.
+func ParseStructured(body []byte) (*Event, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, validationError("cloudevents: malformed structured-mode json: " + err.Error())
+	}
+
+	var se structuredEvent
+	if err := json.Unmarshal(body, &se); err != nil {
+		return nil, validationError("cloudevents: malformed structured-mode json: " + err.Error())
+	}
+
+	e := &Event{
+		ID:              se.ID,
+		Source:          se.Source,
+		SpecVersion:     se.SpecVersion,
+		Type:            se.Type,
+		DataContentType: se.DataContentType,
+		Subject:         se.Subject,
+		Time:            se.Time,
+		Data:            []byte(se.Data),
+	}
+
+	if se.DataBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(se.DataBase64)
+		if err != nil {
+			return nil, validationError("cloudevents: malformed data_base64: " + err.Error())
+		}
+		e.Data = decoded
+	}
+
+	for k, v := range raw {
+		if knownStructuredFields[k] {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			continue // non-string extension attributes aren't supported
+		}
+		if e.Extensions == nil {
+			e.Extensions = make(map[string]string)
+		}
+		e.Extensions[k] = s
+	}
+
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// MarshalJSON renders e in structured mode.
+func (e *Event) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"id":          e.ID,
+		"source":      e.Source,
+		"specversion": e.SpecVersion,
+		"type":        e.Type,
+	}
+	if e.DataContentType != "" {
+		m["datacontenttype"] = e.DataContentType
+	}
+	if e.Subject != "" {
+		m["subject"] = e.Subject
+	}
+	if e.Time != "" {
+		m["time"] = e.Time
+	}
+	if len(e.Data) > 0 {
+		m["data"] = json.RawMessage(e.Data)
+	}
+	for k, v := range e.Extensions {
+		m[k] = v
+	}
+	return json.Marshal(m)
+}
+
+// ParseRequest parses an incoming HTTP request carrying a CloudEvent in
+// either structured mode (Content-Type: application/cloudevents+json) or
+// binary mode (ce-* headers).
+func ParseRequest(r *http.Request, body []byte) (*Event, error) {
+	if r.Header.Get("Content-Type") == ContentType {
+		return ParseStructured(body)
+	}
+	return ParseBinary(r.Header, body)
+}
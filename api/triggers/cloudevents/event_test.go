@@ -0,0 +1,93 @@
+package cloudevents
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseBinary(t *testing.T) {
+	h := http.Header{}
+	h.Set("Ce-Id", "1")
+	h.Set("Ce-Source", "/producer")
+	h.Set("Ce-Specversion", SpecVersion)
+	h.Set("Ce-Type", "com.example.widget.created")
+	h.Set("Ce-Myext", "extra")
+	h.Set("Content-Type", "application/json")
+
+	e, err := ParseBinary(h, []byte(`{"widget":"a"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.ID != "1" || e.Source != "/producer" || e.Type != "com.example.widget.created" {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	if e.Extensions["myext"] != "extra" {
+		t.Fatalf("expected extension myext=extra, got %+v", e.Extensions)
+	}
+}
+
+func TestParseBinaryMissingRequired(t *testing.T) {
+	h := http.Header{}
+	h.Set("Ce-Source", "/producer")
+	if _, err := ParseBinary(h, nil); err == nil {
+		t.Fatal("expected error for missing required id attribute")
+	}
+}
+
+func TestParseStructured(t *testing.T) {
+	body := []byte(`{
+		"id": "1",
+		"source": "/producer",
+		"specversion": "1.0",
+		"type": "com.example.widget.created",
+		"myext": "extra",
+		"data": {"widget":"a"}
+	}`)
+
+	e, err := ParseStructured(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Extensions["myext"] != "extra" {
+		t.Fatalf("expected extension myext=extra, got %+v", e.Extensions)
+	}
+	if string(e.Data) != `{"widget":"a"}` {
+		t.Fatalf("unexpected data: %s", e.Data)
+	}
+}
+
+func TestParseStructuredDataBase64(t *testing.T) {
+	body := []byte(`{
+		"id": "1",
+		"source": "/producer",
+		"specversion": "1.0",
+		"type": "com.example.widget.created",
+		"data_base64": "aGVsbG8="
+	}`)
+
+	e, err := ParseStructured(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(e.Data) != "hello" {
+		t.Fatalf("expected decoded data \"hello\", got %q", e.Data)
+	}
+}
+
+func TestBinaryHeadersRoundTrip(t *testing.T) {
+	e := &Event{
+		ID:          "1",
+		Source:      "/producer",
+		SpecVersion: SpecVersion,
+		Type:        "com.example.widget.created",
+		Extensions:  map[string]string{"myext": "extra"},
+	}
+
+	roundTripped, err := ParseBinary(e.BinaryHeaders(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if roundTripped.ID != e.ID || roundTripped.Extensions["myext"] != "extra" {
+		t.Fatalf("expected round trip to preserve attributes, got %+v", roundTripped)
+	}
+}
@@ -0,0 +1,80 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Filter is a CloudEvents subscription filter expression, following the
+// dialects defined by the CloudEvents Subscriptions API spec: exact,
+// prefix and suffix match individual attributes; all, any and not
+// combine sub-filters.
+type Filter struct {
+	Exact  map[string]string `json:"exact,omitempty"`
+	Prefix map[string]string `json:"prefix,omitempty"`
+	Suffix map[string]string `json:"suffix,omitempty"`
+	All    []*Filter         `json:"all,omitempty"`
+	Any    []*Filter         `json:"any,omitempty"`
+	Not    *Filter           `json:"not,omitempty"`
+}
+
+// ParseFilter parses a Trigger's filter expression. An empty expression
+// parses to a nil Filter, which Matches treats as matching everything.
+func ParseFilter(expr []byte) (*Filter, error) {
+	expr = []byte(strings.TrimSpace(string(expr)))
+	if len(expr) == 0 {
+		return nil, nil
+	}
+
+	var f Filter
+	if err := json.Unmarshal(expr, &f); err != nil {
+		return nil, validationError("cloudevents: malformed trigger filter: " + err.Error())
+	}
+	return &f, nil
+}
+
+// Matches reports whether e satisfies f. A nil Filter matches every event.
+func (f *Filter) Matches(e *Event) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Not != nil && f.Not.Matches(e) {
+		return false
+	}
+	if len(f.All) > 0 {
+		for _, sub := range f.All {
+			if !sub.Matches(e) {
+				return false
+			}
+		}
+	}
+	if len(f.Any) > 0 {
+		matched := false
+		for _, sub := range f.Any {
+			if sub.Matches(e) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for attr, want := range f.Exact {
+		if e.Attribute(attr) != want {
+			return false
+		}
+	}
+	for attr, want := range f.Prefix {
+		if !strings.HasPrefix(e.Attribute(attr), want) {
+			return false
+		}
+	}
+	for attr, want := range f.Suffix {
+		if !strings.HasSuffix(e.Attribute(attr), want) {
+			return false
+		}
+	}
+	return true
+}
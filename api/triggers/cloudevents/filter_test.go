@@ -0,0 +1,71 @@
+package cloudevents
+
+import "testing"
+
+func event(typ, source string) *Event {
+	return &Event{ID: "1", Source: source, SpecVersion: SpecVersion, Type: typ}
+}
+
+func TestFilterNilMatchesEverything(t *testing.T) {
+	var f *Filter
+	if !f.Matches(event("com.example.a", "/x")) {
+		t.Fatal("expected nil filter to match everything")
+	}
+}
+
+func TestFilterExact(t *testing.T) {
+	f, err := ParseFilter([]byte(`{"exact":{"type":"com.example.widget.created"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.Matches(event("com.example.widget.created", "/x")) {
+		t.Fatal("expected exact match")
+	}
+	if f.Matches(event("com.example.widget.deleted", "/x")) {
+		t.Fatal("expected non-match")
+	}
+}
+
+func TestFilterPrefixSuffix(t *testing.T) {
+	f, err := ParseFilter([]byte(`{"prefix":{"source":"/tenants/"},"suffix":{"type":".created"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.Matches(event("com.example.widget.created", "/tenants/42")) {
+		t.Fatal("expected prefix+suffix match")
+	}
+	if f.Matches(event("com.example.widget.deleted", "/tenants/42")) {
+		t.Fatal("expected suffix mismatch to fail")
+	}
+}
+
+func TestFilterAnyAllNot(t *testing.T) {
+	f, err := ParseFilter([]byte(`{
+		"all": [
+			{"any": [{"exact":{"type":"a"}}, {"exact":{"type":"b"}}]},
+			{"not": {"exact":{"source":"/blocked"}}}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.Matches(event("a", "/x")) {
+		t.Fatal("expected any(a,b) and not blocked to match type a")
+	}
+	if f.Matches(event("a", "/blocked")) {
+		t.Fatal("expected blocked source to be excluded")
+	}
+	if f.Matches(event("c", "/x")) {
+		t.Fatal("expected type c to not match any(a,b)")
+	}
+}
+
+func TestParseFilterEmpty(t *testing.T) {
+	f, err := ParseFilter(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f != nil {
+		t.Fatalf("expected nil filter for empty expression, got %+v", f)
+	}
+}
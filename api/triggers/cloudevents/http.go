@@ -0,0 +1,40 @@
+package cloudevents
+
+import (
+	"io/ioutil"
+	"net/http"
+)
+
+// HandleHTTP parses an incoming CloudEvent off r, in whichever mode it
+// was sent in, and dispatches it to appID's matching Triggers.
+func HandleHTTP(d *Dispatcher, appID string, w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ev, err := ParseRequest(r, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	replies, err := d.Dispatch(r.Context(), appID, ev)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if len(replies) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	reply := replies[0]
+	for k, v := range reply.BinaryHeaders() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(reply.Data)
+}
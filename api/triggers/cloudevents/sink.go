@@ -0,0 +1,120 @@
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// EventSink delivers an Event to an external system - Kafka, NATS, an
+// HTTP webhook, or similar. Dispatcher uses it both as a normal
+// dead-letter destination and as the thing BackoffSink retries.
+type EventSink interface {
+	Send(ctx context.Context, e *Event) error
+}
+
+// EventSinkFunc adapts a plain function to the EventSink interface.
+type EventSinkFunc func(ctx context.Context, e *Event) error
+
+// Send implements EventSink.
+func (f EventSinkFunc) Send(ctx context.Context, e *Event) error { return f(ctx, e) }
+
+// HTTPWebhookSink delivers events as structured-mode POSTs to a fixed URL.
+type HTTPWebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPWebhookSink returns an HTTPWebhookSink posting to url using
+// http.DefaultClient.
+func NewHTTPWebhookSink(url string) *HTTPWebhookSink {
+	return &HTTPWebhookSink{URL: url, Client: http.DefaultClient}
+}
+
+// Send implements EventSink.
+func (s *HTTPWebhookSink) Send(ctx context.Context, e *Event) error {
+	body, err := e.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ContentType)
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("cloudevents: webhook sink %s returned status %d", s.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// BackoffSink wraps an EventSink, retrying failed sends with exponential
+// backoff before handing the event to DeadLetter.
+type BackoffSink struct {
+	Sink       EventSink
+	DeadLetter EventSink
+
+	MaxRetries  int
+	InitialWait time.Duration
+	MaxWait     time.Duration
+}
+
+// NewBackoffSink returns a BackoffSink with reasonable defaults: 5
+// retries, starting at 100ms and doubling up to a 30s cap.
+func NewBackoffSink(sink, deadLetter EventSink) *BackoffSink {
+	return &BackoffSink{
+		Sink:        sink,
+		DeadLetter:  deadLetter,
+		MaxRetries:  5,
+		InitialWait: 100 * time.Millisecond,
+		MaxWait:     30 * time.Second,
+	}
+}
+
+// Send implements EventSink, retrying b.Sink and falling back to
+// b.DeadLetter (if set) once retries are exhausted. It returns nil once
+// the event has been delivered or successfully dead-lettered - only a
+// failure to do either of those is reported to the caller.
+func (b *BackoffSink) Send(ctx context.Context, e *Event) error {
+	wait := b.InitialWait
+	var lastErr error
+
+	for attempt := 0; attempt <= b.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			if wait *= 2; wait > b.MaxWait {
+				wait = b.MaxWait
+			}
+		}
+
+		if lastErr = b.Sink.Send(ctx, e); lastErr == nil {
+			return nil
+		}
+	}
+
+	if b.DeadLetter == nil {
+		return lastErr
+	}
+	if dlErr := b.DeadLetter.Send(ctx, e); dlErr != nil {
+		return fmt.Errorf("cloudevents: delivery failed (%v) and dead-letter failed (%v)", lastErr, dlErr)
+	}
+	return nil
+}
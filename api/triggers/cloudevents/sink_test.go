@@ -0,0 +1,84 @@
+package cloudevents
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func sinkFunc(f func() error) EventSinkFunc {
+	return EventSinkFunc(func(ctx context.Context, e *Event) error { return f() })
+}
+
+func TestBackoffSinkSucceedsWithoutRetry(t *testing.T) {
+	b := NewBackoffSink(sinkFunc(func() error { return nil }), nil)
+	b.InitialWait = time.Millisecond
+
+	if err := b.Send(context.Background(), testEvent("com.example.a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBackoffSinkRetriesThenSucceeds(t *testing.T) {
+	attempts := 0
+	sink := sinkFunc(func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	b := NewBackoffSink(sink, nil)
+	b.InitialWait = time.Millisecond
+	b.MaxRetries = 5
+
+	if err := b.Send(context.Background(), testEvent("com.example.a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestBackoffSinkReturnsNilOnSuccessfulDeadLetter(t *testing.T) {
+	sink := sinkFunc(func() error { return errors.New("always fails") })
+	deadLetter := &fakeSink{}
+
+	b := NewBackoffSink(sink, deadLetter)
+	b.InitialWait = time.Millisecond
+	b.MaxRetries = 1
+
+	err := b.Send(context.Background(), testEvent("com.example.a"))
+	if err != nil {
+		t.Fatalf("expected nil error once the event was successfully dead-lettered, got %v", err)
+	}
+	if len(deadLetter.sent) != 1 {
+		t.Fatalf("expected the event to be sent to the dead letter sink, got %d sends", len(deadLetter.sent))
+	}
+}
+
+func TestBackoffSinkReturnsErrorWhenDeadLetterAlsoFails(t *testing.T) {
+	sink := sinkFunc(func() error { return errors.New("always fails") })
+	deadLetter := &fakeSink{err: errors.New("dead letter unavailable")}
+
+	b := NewBackoffSink(sink, deadLetter)
+	b.InitialWait = time.Millisecond
+	b.MaxRetries = 1
+
+	if err := b.Send(context.Background(), testEvent("com.example.a")); err == nil {
+		t.Fatal("expected an error when both the sink and the dead letter fail")
+	}
+}
+
+func TestBackoffSinkReturnsErrorWithNoDeadLetter(t *testing.T) {
+	sink := sinkFunc(func() error { return errors.New("always fails") })
+
+	b := NewBackoffSink(sink, nil)
+	b.InitialWait = time.Millisecond
+	b.MaxRetries = 1
+
+	if err := b.Send(context.Background(), testEvent("com.example.a")); err == nil {
+		t.Fatal("expected an error when the sink fails and there is no dead letter")
+	}
+}